@@ -0,0 +1,155 @@
+// Command legv8sim disassembles a file of 32-bit LEGv8 instruction words
+// (one binary string per line) and simulates them, writing a disassembly
+// listing and a per-cycle simulation trace.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"legv8sim/pkg/cpu"
+	"legv8sim/pkg/disasm"
+	"legv8sim/pkg/simulator"
+)
+
+func main() {
+	var inputFileName *string
+	var outputFileName *string
+	var simOutputFileName *string // New flag for simulation output file
+
+	// Define flags
+	inputFileName = flag.String("i", "", "Input file name")
+	outputFileName = flag.String("o", "", "Output file name")
+	simOutputFileName = flag.String("s", "", "Simulation output file name") // New flag for simulation output file
+	pipeline := flag.Bool("pipeline", false, "Simulate with a 5-stage IF/ID/EX/MEM/WB pipeline instead of single-cycle")
+	syntax := flag.String("syntax", "raw", "Disassembly syntax: raw (column-aligned bits+mnemonic) or gnu (resolved branch targets, MOV alias)")
+
+	// Parse the command-line arguments
+	flag.Parse()
+
+	// Check if both input and output files were not provided
+	if *inputFileName == "" && *outputFileName == "" {
+		fmt.Println("Both input and output file names are required. Use -i and -o flags.")
+		return
+	}
+
+	*outputFileName = *outputFileName + "_dis.txt"
+	if *simOutputFileName == "" {
+		*simOutputFileName = *outputFileName + "_sim.txt"
+	} else {
+		*simOutputFileName = *simOutputFileName + "_sim.txt"
+	}
+
+	// Open input file
+	inputFile, err := os.Open(*inputFileName)
+	if err != nil {
+		fmt.Println("Error opening input file:", err)
+		return
+	}
+	defer inputFile.Close()
+
+	// Open output file
+	outputFile, err := os.Create(*outputFileName)
+	if err != nil {
+		fmt.Println("Error creating output file:", err)
+		return
+	}
+	defer outputFile.Close()
+
+	// Open simulation output file
+	simOutputFile, err := os.Create(*simOutputFileName)
+	if err != nil {
+		fmt.Println("Error creating simulation output file:", err)
+		return
+	}
+	defer simOutputFile.Close()
+
+	const startPC = 96
+	c := cpu.New(startPC)
+
+	// Decode pass: walk the file once, writing the disassembly listing and
+	// collecting the decoded instructions up to and including the first BREAK
+	// so the simulate pass below can share the exact same Instruction values.
+	breakFound := false
+	var program []disasm.Instruction
+	addr := int64(startPC)
+
+	scanner := bufio.NewScanner(inputFile)
+	for scanner.Scan() {
+		line := scanner.Text()
+		binaryNumber := strings.TrimSpace(line)
+		if len(binaryNumber) != 32 {
+			invalidString := fmt.Sprintf("%.32s Invalid binary string! \n", binaryNumber)
+			outputFile.WriteString(invalidString)
+			continue
+		}
+
+		word, err := strconv.ParseUint(binaryNumber, 2, 32)
+		if err != nil {
+			invalidString := fmt.Sprintf("%.32s Invalid binary string! \n", binaryNumber)
+			outputFile.WriteString(invalidString)
+			continue
+		}
+
+		if breakFound {
+			afterBreak := fmt.Sprintf("%.32s \t\t%d\t%d \n", binaryNumber, addr, int32(word))
+			outputFile.WriteString(afterBreak)
+			addr += 4
+			continue
+		}
+
+		inst, err := disasm.Decode(uint32(word))
+		if err != nil {
+			opcodeUnknown := fmt.Sprintf("%s \tUnknown Instruction!\n", disasm.RawPrefixUnknown(binaryNumber))
+			outputFile.WriteString(opcodeUnknown)
+			addr += 4
+			continue
+		}
+
+		if inst.Op == disasm.OpBREAK {
+			breakFound = true
+		}
+		switch {
+		case *syntax == "gnu":
+			outputFile.WriteString(fmt.Sprintf("%d:\t%s\n", addr, disasm.RenderGNU(inst, addr)))
+		case inst.Op == disasm.OpBREAK:
+			disassembled := fmt.Sprintf("%s \t%d\t%s \n", disasm.RawPrefixBreak(binaryNumber), addr, inst.Op)
+			outputFile.WriteString(disassembled)
+		case inst.Op == disasm.OpNOP:
+			disassembled := fmt.Sprintf("%.32s \t\t%d\t%s \n", binaryNumber, addr, inst.Op)
+			outputFile.WriteString(disassembled)
+		default:
+			disassembled := fmt.Sprintf("%s \t%d\t%s\t%s \n", disasm.RawPrefix(inst.Format, binaryNumber), addr, inst.Op, disasm.FormatOperands(inst))
+			outputFile.WriteString(disassembled)
+		}
+
+		program = append(program, inst)
+		addr += 4
+	}
+
+	if *pipeline {
+		if err := simulator.RunPipeline(c, program, simOutputFile); err != nil {
+			reportMachineError(err)
+		}
+		return
+	}
+
+	if err := simulator.Run(c, program, simOutputFile); err != nil {
+		reportMachineError(err)
+	}
+}
+
+// reportMachineError prints err to stderr, unless it is the expected
+// ErrBreakHit signaling a clean program stop.
+func reportMachineError(err error) {
+	var machineErr *simulator.MachineError
+	if errors.As(err, &machineErr) && machineErr.Kind == simulator.ErrBreakHit {
+		return
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
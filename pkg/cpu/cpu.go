@@ -0,0 +1,27 @@
+// Package cpu holds the architectural state of the simulated LEGv8 machine:
+// general-purpose registers, data memory, the instruction address, and the
+// condition flags. It has no knowledge of decoding or execution; those live
+// in pkg/disasm and pkg/simulator respectively.
+package cpu
+
+// Flags holds the condition codes (Negative, Zero, Carry, oVerflow).
+type Flags struct {
+	N, Z, C, V bool
+}
+
+// CPU is the full architectural state threaded through a simulator run.
+type CPU struct {
+	Registers [32]int64
+	Memory    map[int64]int64
+	PC        int64
+	Flags     Flags
+}
+
+// New returns a CPU with its memory map initialized and every register
+// zeroed, with PC set to the start of the program's instruction stream.
+func New(startPC int64) *CPU {
+	return &CPU{
+		Memory: make(map[int64]int64),
+		PC:     startPC,
+	}
+}
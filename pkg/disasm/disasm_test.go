@@ -0,0 +1,82 @@
+package disasm
+
+import "testing"
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		name   string
+		word   uint32
+		op     Op
+		format Format
+		fields map[string]int64
+	}{
+		{
+			name:   "ADD",
+			word:   0x8B020041, // ADD X1, X2, X2
+			op:     OpADD,
+			format: FormatR,
+			fields: map[string]int64{"rm": 2, "shamt": 0, "rn": 2, "rd": 1},
+		},
+		{
+			name:   "ADDI",
+			word:   0x91000421, // ADDI X1, X1, #1
+			op:     OpADDI,
+			format: FormatI,
+			fields: map[string]int64{"immediate": 1, "rn": 1, "rd": 1},
+		},
+		{
+			name:   "STUR unsigned address",
+			word:   0xF81FF020, // STUR X0, [X1, #511]
+			op:     OpSTUR,
+			format: FormatD,
+			fields: map[string]int64{"address": 511, "rn": 1, "rt": 0},
+		},
+		{
+			name:   "CBNZ negative offset",
+			word:   0xB5FFFFE1, // CBNZ X1, #-1
+			op:     OpCBNZ,
+			format: FormatCB,
+			fields: map[string]int64{"offset": -1, "rt": 1},
+		},
+		{
+			name:   "NOP",
+			word:   0x00000000,
+			op:     OpNOP,
+			format: FormatNOP,
+			fields: map[string]int64{},
+		},
+		{
+			name:   "BREAK",
+			word:   0xFEDEFFE7,
+			op:     OpBREAK,
+			format: FormatBreak,
+			fields: map[string]int64{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			inst, err := Decode(c.word)
+			if err != nil {
+				t.Fatalf("Decode(0x%08X) returned error: %v", c.word, err)
+			}
+			if inst.Op != c.op {
+				t.Errorf("Op = %s, want %s", inst.Op, c.op)
+			}
+			if inst.Format != c.format {
+				t.Errorf("Format = %d, want %d", inst.Format, c.format)
+			}
+			for name, want := range c.fields {
+				if got := inst.Fields[name]; got != want {
+					t.Errorf("Fields[%q] = %d, want %d", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	if _, err := Decode(0xFFFFFFFF); err == nil {
+		t.Fatal("Decode(0xFFFFFFFF) returned nil error for an unrecognized word")
+	}
+}
@@ -0,0 +1,60 @@
+package disasm
+
+import "fmt"
+
+// regName renders a LEGv8 register number the way GNU syntax does: X0-X30,
+// and XZR for the architectural zero register (encoding 31).
+func regName(n int64) string {
+	if n == 31 {
+		return "XZR"
+	}
+	return fmt.Sprintf("X%d", n)
+}
+
+// hexSigned renders v as GNU-style signed hex, e.g. 8 -> "0x8", -8 -> "-0x8".
+func hexSigned(v int64) string {
+	if v < 0 {
+		return fmt.Sprintf("-0x%X", -v)
+	}
+	return fmt.Sprintf("0x%X", v)
+}
+
+// RenderGNU formats inst the way ppc64asm.GNUSyntax formats an instruction:
+// PC-relative branches resolved to an absolute target address, negative
+// load/store displacements rendered as "[Xn, -0x...]", and "ADDI Xd, XZR,
+// #k" canonicalized to the "MOV Xd, #k" alias. addr is the instruction's own
+// address, needed to resolve branch targets.
+func RenderGNU(inst Instruction, addr int64) string {
+	f := inst.Fields
+	switch inst.Format {
+	case FormatR:
+		return fmt.Sprintf("%s %s, %s, %s", inst.Op, regName(f["rd"]), regName(f["rn"]), regName(f["rm"]))
+	case FormatShift:
+		return fmt.Sprintf("%s %s, %s, #%d", inst.Op, regName(f["rd"]), regName(f["rn"]), f["shamt"])
+	case FormatD:
+		// The decoded "address" field is unsigned (it matches baseline's
+		// unadjusted DT9 parse); GNU syntax still renders the displacement
+		// as 2's-complement, so sign-extend just for display here.
+		disp := signExtendTo(f["address"], 9)
+		return fmt.Sprintf("%s %s, [%s, %s]", inst.Op, regName(f["rt"]), regName(f["rn"]), hexSigned(disp))
+	case FormatI:
+		if inst.Op == OpADDI && f["rn"] == 31 {
+			return fmt.Sprintf("MOV %s, #%d", regName(f["rd"]), f["immediate"])
+		}
+		return fmt.Sprintf("%s %s, %s, #%d", inst.Op, regName(f["rd"]), regName(f["rn"]), f["immediate"])
+	case FormatB:
+		target := addr + f["offset"]*4
+		return fmt.Sprintf("%s %s", inst.Op, hexSigned(target))
+	case FormatCB:
+		target := addr + f["offset"]*4
+		return fmt.Sprintf("%s %s, %s", inst.Op, regName(f["rt"]), hexSigned(target))
+	case FormatIM:
+		return fmt.Sprintf("%s %s, %d, LSL %d", inst.Op, regName(f["rd"]), f["field"], f["shiftcode"])
+	case FormatNOP:
+		return "NOP"
+	case FormatBreak:
+		return "BREAK"
+	default:
+		return inst.Op.String()
+	}
+}
@@ -0,0 +1,56 @@
+package disasm
+
+import "strings"
+
+// rawFieldWidths gives the bit-group widths the baseline single-file
+// disassembler printed ahead of the mnemonic in -syntax=raw mode, e.g. R and
+// SHIFT format lines read "<opcode:11> <rm:5> <shamt:6> <rn:5> <rd:5>".
+var rawFieldWidths = map[Format][]int{
+	FormatR:     {11, 5, 6, 5, 5},
+	FormatShift: {11, 5, 6, 5, 5},
+	FormatD:     {11, 9, 2, 5, 5},
+	FormatI:     {10, 12, 5, 5},
+	FormatB:     {6, 26},
+	FormatCB:    {8, 19, 5},
+	FormatIM:    {9, 2, 16, 5},
+}
+
+// rawBreakWidths and rawUnknownWidths match the baseline's BREAK and
+// catch-all "Unknown Instruction!" groupings respectively.
+var rawBreakWidths = []int{1, 5, 5, 5, 5, 5, 6}
+var rawUnknownWidths = []int{8, 3, 5, 5, 5, 6}
+
+// splitFields groups the 32-character bit string according to widths,
+// joining each group with a single space.
+func splitFields(bits string, widths []int) string {
+	groups := make([]string, 0, len(widths))
+	pos := 0
+	for _, w := range widths {
+		groups = append(groups, bits[pos:pos+w])
+		pos += w
+	}
+	return strings.Join(groups, " ")
+}
+
+// RawPrefix renders the bit-group prefix for a decoded instruction's raw
+// disassembly line. FormatNOP has no baseline grouping (the original NOP
+// branch printed the bits unsplit), so callers should use bits directly for
+// that format instead of calling RawPrefix.
+func RawPrefix(format Format, bits string) string {
+	widths, ok := rawFieldWidths[format]
+	if !ok {
+		return bits
+	}
+	return splitFields(bits, widths)
+}
+
+// RawPrefixBreak renders the BREAK instruction's bit-group prefix.
+func RawPrefixBreak(bits string) string {
+	return splitFields(bits, rawBreakWidths)
+}
+
+// RawPrefixUnknown renders the catch-all "Unknown Instruction!" bit-group
+// prefix used when a word matches no instFormat row.
+func RawPrefixUnknown(bits string) string {
+	return splitFields(bits, rawUnknownWidths)
+}
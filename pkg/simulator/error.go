@@ -0,0 +1,50 @@
+package simulator
+
+import "fmt"
+
+// ErrorKind classifies the ways execution can fail, replacing the old
+// panic(e)/discarded-strconv-error/"Unknown instruction type" fall-throughs
+// with values a caller can switch on.
+type ErrorKind int
+
+const (
+	ErrInvalidEncoding ErrorKind = iota
+	ErrUnalignedAccess
+	ErrOutOfBoundsMemory
+	ErrUnknownOpcode
+	ErrBreakHit
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrInvalidEncoding:
+		return "ErrInvalidEncoding"
+	case ErrUnalignedAccess:
+		return "ErrUnalignedAccess"
+	case ErrOutOfBoundsMemory:
+		return "ErrOutOfBoundsMemory"
+	case ErrUnknownOpcode:
+		return "ErrUnknownOpcode"
+	case ErrBreakHit:
+		return "ErrBreakHit"
+	default:
+		return "ErrUnknown"
+	}
+}
+
+// MachineError carries the PC and raw instruction word a failure occurred
+// at, so callers can print "pc=0x84 instr=0xF8000020 ErrUnalignedAccess:
+// unaligned STUR to 0x67" instead of a panic stack trace. Run returns the first MachineError
+// it hits with this context attached; every error-returning function in this
+// package follows the normal Go (value, error) convention rather than
+// panicking.
+type MachineError struct {
+	Kind  ErrorKind
+	PC    int64
+	Instr uint32
+	Msg   string
+}
+
+func (e *MachineError) Error() string {
+	return fmt.Sprintf("pc=0x%X instr=0x%08X %s: %s", e.PC, e.Instr, e.Kind, e.Msg)
+}
@@ -0,0 +1,115 @@
+package simulator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"legv8sim/pkg/cpu"
+)
+
+// ExceptionType classifies why the simulator trapped out of normal
+// instruction execution.
+type ExceptionType int
+
+const (
+	SyscallException ExceptionType = iota
+	PageFaultException
+	IllegalInstrException
+	OverflowException
+	BusErrorException
+)
+
+func (e ExceptionType) String() string {
+	switch e {
+	case SyscallException:
+		return "SyscallException"
+	case PageFaultException:
+		return "PageFaultException"
+	case IllegalInstrException:
+		return "IllegalInstrException"
+	case OverflowException:
+		return "OverflowException"
+	case BusErrorException:
+		return "BusErrorException"
+	default:
+		return "UnknownException"
+	}
+}
+
+// Syscall numbers, passed to BREAK in X0. BREAK with X0 == 0 is the plain
+// "halt" form kept for backward compatibility with existing programs.
+const (
+	scShutdown int64 = iota + 1
+	scExit
+	scWrite
+	scRead
+	scYield
+)
+
+// syscallTable dispatches on the value left in X0 when BREAK executes. Each
+// handler reports whether the simulator should stop running.
+var syscallTable = map[int64]func(*cpu.CPU) bool{
+	scShutdown: func(c *cpu.CPU) bool { return true },
+	scExit:     func(c *cpu.CPU) bool { return true },
+	scWrite:    sysWrite,
+	scRead:     sysRead,
+	scYield:    func(c *cpu.CPU) bool { return false },
+}
+
+// sysWrite writes X2 words starting at memory address X1 to stdout, one per
+// line, mimicking a minimal write(2). Memory is addressed in bytes the same
+// way STUR/LDUR address it (simulator.go), so each word is 8 bytes apart.
+func sysWrite(c *cpu.CPU) bool {
+	addr, count := c.Registers[1], c.Registers[2]
+	for i := int64(0); i < count; i++ {
+		fmt.Fprintln(os.Stdout, c.Memory[addr+i*8])
+	}
+	return false
+}
+
+// sysRead reads X2 words from stdin into memory starting at address X1,
+// mimicking a minimal read(2). See sysWrite for the byte-addressing note.
+func sysRead(c *cpu.CPU) bool {
+	addr, count := c.Registers[1], c.Registers[2]
+	reader := bufio.NewReader(os.Stdin)
+	for i := int64(0); i < count; i++ {
+		var v int64
+		if _, err := fmt.Fscan(reader, &v); err != nil {
+			break
+		}
+		c.Memory[addr+i*8] = v
+	}
+	return false
+}
+
+// RaiseException reports a trap of the given kind to stderr. badAddr carries
+// the faulting address for exceptions where one applies (PageFaultException,
+// BusErrorException) and is ignored otherwise. It is the seam future MMU and
+// thread support hang additional handling off of; today only BREAK's
+// SyscallException path is wired up.
+func RaiseException(c *cpu.CPU, kind ExceptionType, badAddr int64) {
+	switch kind {
+	case PageFaultException, BusErrorException:
+		fmt.Fprintf(os.Stderr, "%s at pc=0x%X addr=0x%X\n", kind, c.PC, badAddr)
+	default:
+		fmt.Fprintf(os.Stderr, "%s at pc=0x%X\n", kind, c.PC)
+	}
+}
+
+// dispatchBreak implements the BREAK trap: X0 == 0 halts immediately for
+// backward compatibility, otherwise X0 is a syscall number looked up in
+// syscallTable.
+func dispatchBreak(c *cpu.CPU) bool {
+	syscallNum := c.Registers[0]
+	if syscallNum == 0 {
+		return true
+	}
+	RaiseException(c, SyscallException, 0)
+	handler, ok := syscallTable[syscallNum]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown syscall number %d at pc=0x%X\n", syscallNum, c.PC)
+		return true
+	}
+	return handler(c)
+}
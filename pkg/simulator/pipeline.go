@@ -0,0 +1,213 @@
+package simulator
+
+import (
+	"fmt"
+	"io"
+
+	"legv8sim/pkg/cpu"
+	"legv8sim/pkg/disasm"
+)
+
+// pipelineStage names one of the five classic stages.
+type pipelineStage int
+
+const (
+	stageIF pipelineStage = iota
+	stageID
+	stageEX
+	stageMEM
+	stageWB
+	numStages
+)
+
+func (s pipelineStage) String() string {
+	return [numStages]string{"IF", "ID", "EX", "MEM", "WB"}[s]
+}
+
+// pipelineSlot is the content of one pipeline latch: either a real
+// instruction in flight, or a bubble/flush marker with no instruction.
+type pipelineSlot struct {
+	inst   disasm.Instruction
+	addr   int64
+	valid  bool
+	bubble bool // stall bubble: no instruction, waiting on a hazard
+	flush  bool // squashed by a taken branch
+}
+
+// destRegister reports the architectural register inst writes, if any.
+func destRegister(inst disasm.Instruction) (int, bool) {
+	switch inst.Op {
+	case disasm.OpAND, disasm.OpADD, disasm.OpSUB, disasm.OpEOR, disasm.OpORR,
+		disasm.OpLSL, disasm.OpLSR, disasm.OpASR, disasm.OpADDI, disasm.OpSUBI,
+		disasm.OpMOVZ, disasm.OpMOVK, disasm.OpLDUR:
+		rd := "rd"
+		if inst.Op == disasm.OpLDUR {
+			rd = "rt"
+		}
+		return int(inst.Fields[rd]), true
+	default:
+		return 0, false
+	}
+}
+
+// sourceRegisters reports the architectural registers inst reads.
+func sourceRegisters(inst disasm.Instruction) []int {
+	f := inst.Fields
+	switch inst.Op {
+	case disasm.OpAND, disasm.OpADD, disasm.OpSUB, disasm.OpEOR, disasm.OpORR:
+		return []int{int(f["rn"]), int(f["rm"])}
+	case disasm.OpLSL, disasm.OpLSR, disasm.OpASR, disasm.OpADDI, disasm.OpSUBI, disasm.OpLDUR:
+		return []int{int(f["rn"])}
+	case disasm.OpSTUR:
+		return []int{int(f["rn"]), int(f["rt"])}
+	case disasm.OpCBZ, disasm.OpCBNZ:
+		return []int{int(f["rt"])}
+	default:
+		return nil
+	}
+}
+
+// isTakenBranch reports whether inst, having just executed against c,
+// redirected control flow.
+func isTakenBranch(inst disasm.Instruction, c *cpu.CPU) bool {
+	switch inst.Op {
+	case disasm.OpB:
+		return true
+	case disasm.OpCBZ:
+		return c.Registers[inst.Fields["rt"]] == 0
+	case disasm.OpCBNZ:
+		return c.Registers[inst.Fields["rt"]] != 0
+	default:
+		return false
+	}
+}
+
+// hazard reports whether any source register of inst is written by the
+// not-yet-resolved instruction sitting in ex, in which case the consumer
+// must stall in ID rather than issue this cycle. A dependency on an
+// instruction already in MEM is assumed to be satisfiable by forwarding and
+// does not stall.
+func hazard(inst disasm.Instruction, ex *pipelineSlot) bool {
+	if ex == nil || !ex.valid || ex.bubble {
+		return false
+	}
+	destReg, ok := destRegister(ex.inst)
+	if !ok {
+		return false
+	}
+	for _, src := range sourceRegisters(inst) {
+		if src == destReg {
+			return true
+		}
+	}
+	return false
+}
+
+// RunPipeline executes program against c using a 5-stage IF/ID/EX/MEM/WB
+// pipeline: each cycle every instruction in flight advances one latch,
+// RAW hazards on registers still in EX stall the consumer in ID (forwarding
+// from MEM/WB is assumed free), and a taken branch flushes IF/ID. A per-cycle
+// diagram is written to trace. It returns the first MachineError encountered
+// (including ErrBreakHit on a normal BREAK halt).
+func RunPipeline(c *cpu.CPU, program []disasm.Instruction, trace io.Writer) error {
+	startPC := c.PC
+	addrOf := func(i int) int64 { return startPC + int64(i)*4 }
+
+	var stages [numStages]*pipelineSlot
+	fetchIdx := 0
+	cycle := 0
+	var halted bool
+	var stepErr error
+
+	for {
+		cycle++
+
+		// WB: nothing to do, the result was already written when the
+		// instruction left EX/MEM; WB only retires the slot.
+		stages[stageWB] = stages[stageMEM]
+
+		// MEM: instructions reaching MEM perform their memory access and
+		// register write-back to keep forwarding simple (single Step
+		// call covers EX+MEM+WB worth of architectural effect).
+		memSlot := stages[stageEX]
+		if memSlot != nil && memSlot.valid && !memSlot.bubble {
+			c.PC = memSlot.addr
+			if h, err := Step(c, memSlot.inst); h {
+				halted = true
+				stepErr = err
+			}
+			if isTakenBranch(memSlot.inst, c) {
+				stages[stageIF] = &pipelineSlot{flush: true}
+				stages[stageID] = &pipelineSlot{flush: true}
+				target := memSlot.addr + memSlot.inst.Fields["offset"]*4
+				next := (target - startPC) / 4
+				if next < 0 || next >= int64(len(program)) {
+					return &MachineError{Kind: ErrOutOfBoundsMemory, PC: c.PC, Instr: memSlot.inst.Word, Msg: fmt.Sprintf("branch target 0x%X has no instruction", target)}
+				}
+				fetchIdx = int(next)
+			}
+		}
+		stages[stageMEM] = memSlot
+
+		// EX: advance whatever was waiting in ID; a stall bubble in ID
+		// moves into EX the same way an instruction does.
+		stages[stageEX] = stages[stageID]
+
+		// ID: decode/issue the instruction sitting in IF, unless a RAW
+		// hazard against the instruction currently in EX forces a bubble.
+		ifSlot := stages[stageIF]
+		if ifSlot != nil && ifSlot.valid && !ifSlot.flush && hazard(ifSlot.inst, stages[stageEX]) {
+			stages[stageID] = &pipelineSlot{bubble: true}
+			// ifSlot stays in IF; do not fetch a new instruction.
+		} else {
+			stages[stageID] = ifSlot
+			// IF: fetch the next instruction, if any remain.
+			if fetchIdx < len(program) {
+				stages[stageIF] = &pipelineSlot{inst: program[fetchIdx], addr: addrOf(fetchIdx), valid: true}
+				fetchIdx++
+			} else {
+				stages[stageIF] = nil
+			}
+		}
+
+		writePipelineCycle(trace, cycle, stages, c)
+
+		if halted {
+			return stepErr
+		}
+		if allEmpty(stages) && fetchIdx >= len(program) {
+			return nil
+		}
+	}
+}
+
+func allEmpty(stages [numStages]*pipelineSlot) bool {
+	for _, s := range stages {
+		if s != nil && (s.valid || s.bubble) {
+			return false
+		}
+	}
+	return true
+}
+
+// writePipelineCycle writes one row of the pipeline diagram plus the usual
+// register/memory dump for this cycle.
+func writePipelineCycle(w io.Writer, cycle int, stages [numStages]*pipelineSlot, c *cpu.CPU) {
+	fmt.Fprint(w, "====================\n")
+	fmt.Fprintf(w, "cycle: %d\n", cycle)
+	for stage := stageIF; stage < numStages; stage++ {
+		s := stages[stage]
+		switch {
+		case s == nil || (!s.valid && !s.bubble && !s.flush):
+			fmt.Fprintf(w, "\t%s: -\n", stage)
+		case s.flush:
+			fmt.Fprintf(w, "\t%s: flushed\n", stage)
+		case s.bubble:
+			fmt.Fprintf(w, "\t%s: stall\n", stage)
+		default:
+			fmt.Fprintf(w, "\t%s: %d (%s)\n", stage, s.addr, s.inst.Op)
+		}
+	}
+	fmt.Fprint(w, "\n")
+	writeRegistersAndMemory(w, c)
+}
@@ -0,0 +1,56 @@
+package simulator
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"legv8sim/pkg/cpu"
+	"legv8sim/pkg/disasm"
+)
+
+func TestRunPipelineStallsOnHazard(t *testing.T) {
+	// ADD X1, X2, X3 then SUB X4, X1, X1 immediately consumes X1, so EX must
+	// stall ID by one cycle before the SUB can issue.
+	program := []disasm.Instruction{
+		{Op: disasm.OpADD, Format: disasm.FormatR, Fields: map[string]int64{"rd": 1, "rn": 2, "rm": 3}},
+		{Op: disasm.OpSUB, Format: disasm.FormatR, Fields: map[string]int64{"rd": 4, "rn": 1, "rm": 1}},
+		{Op: disasm.OpBREAK, Fields: map[string]int64{}},
+	}
+
+	c := cpu.New(96)
+	c.Registers[2] = 5
+	c.Registers[3] = 2
+
+	var trace strings.Builder
+	if err := RunPipeline(c, program, &trace); err == nil {
+		t.Fatal("RunPipeline() returned nil error, want the BREAK's ErrBreakHit")
+	}
+	if !strings.Contains(trace.String(), "\tID: stall\n") {
+		t.Error("pipeline trace has no ID stall bubble for the SUB's dependency on X1")
+	}
+	if got, want := c.Registers[1], int64(7); got != want {
+		t.Errorf("X1 = %d, want %d", got, want)
+	}
+	if got, want := c.Registers[4], int64(0); got != want {
+		t.Errorf("X4 = %d, want %d (SUB X1, X1 after the hazard resolves)", got, want)
+	}
+}
+
+func TestRunPipelineFlushesOnTakenBranch(t *testing.T) {
+	// B always branches over the ADD X1, X2, X2, so X1 must stay 0.
+	program := []disasm.Instruction{
+		{Op: disasm.OpB, Format: disasm.FormatB, Fields: map[string]int64{"offset": 2}},
+		{Op: disasm.OpADD, Format: disasm.FormatR, Fields: map[string]int64{"rd": 1, "rn": 2, "rm": 2}},
+		{Op: disasm.OpBREAK, Fields: map[string]int64{}},
+	}
+
+	c := cpu.New(96)
+	c.Registers[2] = 5
+	if err := RunPipeline(c, program, io.Discard); err == nil {
+		t.Fatal("RunPipeline() returned nil error, want the BREAK's ErrBreakHit")
+	}
+	if got := c.Registers[1]; got != 0 {
+		t.Errorf("X1 = %d, want 0 (ADD should have been flushed)", got)
+	}
+}
@@ -0,0 +1,169 @@
+// Package simulator executes decoded LEGv8 instructions against a cpu.CPU.
+// It is the one place that applies an Instruction's effect to architectural
+// state, so the disassembler and the execution loop can never disagree about
+// what an instruction does.
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"legv8sim/pkg/cpu"
+	"legv8sim/pkg/disasm"
+)
+
+// Step applies inst to c, following the LEGv8-as-register-15-holds-PC
+// convention used by branch instructions. It reports whether execution
+// should stop (a BREAK was hit) and, on failure, a *MachineError carrying
+// the PC and instruction word the failure occurred at.
+func Step(c *cpu.CPU, inst disasm.Instruction) (bool, error) {
+	f := inst.Fields
+	switch inst.Op {
+	case disasm.OpAND:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] & c.Registers[f["rm"]]
+	case disasm.OpADD:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] + c.Registers[f["rm"]]
+	case disasm.OpSUB:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] - c.Registers[f["rm"]]
+	case disasm.OpEOR:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] ^ c.Registers[f["rm"]]
+	case disasm.OpORR:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] | c.Registers[f["rm"]]
+	case disasm.OpLSL:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] << uint(f["shamt"])
+	case disasm.OpLSR:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] >> uint(f["shamt"])
+	case disasm.OpASR:
+		c.Registers[f["rd"]] = int64(int32(c.Registers[f["rn"]]) >> uint(f["shamt"]))
+	case disasm.OpSTUR:
+		addr := c.Registers[f["rn"]] + f["address"]
+		if addr%8 != 0 {
+			return true, &MachineError{Kind: ErrUnalignedAccess, PC: c.PC, Instr: inst.Word, Msg: fmt.Sprintf("unaligned STUR to 0x%X", addr)}
+		}
+		if addr < 0 {
+			return true, &MachineError{Kind: ErrOutOfBoundsMemory, PC: c.PC, Instr: inst.Word, Msg: fmt.Sprintf("negative address 0x%X", addr)}
+		}
+		c.Memory[addr] = c.Registers[f["rt"]]
+	case disasm.OpLDUR:
+		addr := c.Registers[f["rn"]] + f["address"]
+		if addr%8 != 0 {
+			return true, &MachineError{Kind: ErrUnalignedAccess, PC: c.PC, Instr: inst.Word, Msg: fmt.Sprintf("unaligned LDUR from 0x%X", addr)}
+		}
+		if addr < 0 {
+			return true, &MachineError{Kind: ErrOutOfBoundsMemory, PC: c.PC, Instr: inst.Word, Msg: fmt.Sprintf("negative address 0x%X", addr)}
+		}
+		c.Registers[f["rt"]] = c.Memory[addr]
+	case disasm.OpADDI:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] + f["immediate"]
+	case disasm.OpSUBI:
+		c.Registers[f["rd"]] = c.Registers[f["rn"]] - f["immediate"]
+	case disasm.OpB:
+		c.Registers[15] += f["offset"] * 4
+	case disasm.OpCBZ:
+		if c.Registers[f["rt"]] == 0 {
+			c.Registers[15] += f["offset"] * 4
+		}
+	case disasm.OpCBNZ:
+		if c.Registers[f["rt"]] != 0 {
+			c.Registers[15] += f["offset"] * 4
+		}
+	case disasm.OpMOVZ:
+		c.Registers[f["rd"]] = f["field"] << uint(f["shiftcode"])
+	case disasm.OpMOVK:
+		mask := int64(0xFFFF) << uint(f["shiftcode"])
+		c.Registers[f["rd"]] = (c.Registers[f["rd"]] &^ mask) | (f["field"] << uint(f["shiftcode"]))
+	case disasm.OpNOP:
+		// no architectural effect
+	case disasm.OpBREAK:
+		if dispatchBreak(c) {
+			return true, &MachineError{Kind: ErrBreakHit, PC: c.PC, Instr: inst.Word, Msg: "BREAK halted execution"}
+		}
+		return false, nil
+	default:
+		return true, &MachineError{Kind: ErrUnknownOpcode, PC: c.PC, Instr: inst.Word, Msg: "no execution semantics for this opcode"}
+	}
+	return false, nil
+}
+
+// Run executes program against c in single-cycle mode, following taken
+// B/CBZ/CBNZ branches to the matching instruction the same way RunPipeline
+// resolves them (rather than just walking the slice in order), and writes a
+// per-cycle trace to trace. It returns the first MachineError encountered
+// (including ErrBreakHit on a normal BREAK halt).
+func Run(c *cpu.CPU, program []disasm.Instruction, trace io.Writer) error {
+	startPC := c.PC
+	addrIndex := make(map[int64]int, len(program))
+	for i := range program {
+		addrIndex[startPC+int64(i)*4] = i
+	}
+
+	cycle := 1
+	for idx := 0; idx < len(program); {
+		inst := program[idx]
+		halted, err := Step(c, inst)
+
+		fmt.Fprintf(trace, "=====================\ncycle:%d %d %s\n", cycle, c.PC, inst.Op)
+		PrintState(trace, cycle, c.PC, inst.Op.String(), c)
+
+		if halted {
+			return err
+		}
+
+		if isTakenBranch(inst, c) {
+			target := c.PC + inst.Fields["offset"]*4
+			next, ok := addrIndex[target]
+			if !ok {
+				return &MachineError{Kind: ErrOutOfBoundsMemory, PC: c.PC, Instr: inst.Word, Msg: fmt.Sprintf("branch target 0x%X has no instruction", target)}
+			}
+			idx = next
+			c.PC = target
+		} else {
+			c.PC += 4
+			idx++
+		}
+		cycle++
+	}
+	return nil
+}
+
+// PrintState writes a snapshot of c's registers and memory to w, in the same
+// format the original single-file simulator used for its -s output.
+func PrintState(w io.Writer, cycle int, instructionAddr int64, instruction string, c *cpu.CPU) {
+	fmt.Fprint(w, "====================\n")
+	fmt.Fprintf(w, "cycle: %d\tinstruction address: %d\tinstruction string: %s\n\n", cycle, instructionAddr, instruction)
+	writeRegistersAndMemory(w, c)
+}
+
+// writeRegistersAndMemory writes the register file and data memory dump
+// shared by PrintState and writePipelineCycle.
+func writeRegistersAndMemory(w io.Writer, c *cpu.CPU) {
+	fmt.Fprint(w, "registers:\n")
+	for i := 0; i < 32; i += 8 {
+		fmt.Fprintf(w, "r%02d:\t", i)
+		for j := i; j < i+8; j++ {
+			fmt.Fprintf(w, "%d\t", c.Registers[j])
+		}
+		fmt.Fprint(w, "\n")
+	}
+	fmt.Fprint(w, "\n")
+	fmt.Fprint(w, "data:\n")
+	// Memory keys are always 8-byte-aligned addresses, so group every 8
+	// entries (by position in sorted order, not by address%8) onto one line.
+	// Iterate in sorted order since range over a map is nondeterministic.
+	addrs := make([]int64, 0, len(c.Memory))
+	for address := range c.Memory {
+		addrs = append(addrs, address)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i] < addrs[j] })
+	for i, address := range addrs {
+		if i%8 == 0 {
+			fmt.Fprintf(w, "%d:\t", address)
+		}
+		fmt.Fprintf(w, "%d\t", c.Memory[address])
+		if i%8 == 7 {
+			fmt.Fprint(w, "\n")
+		}
+	}
+	fmt.Fprint(w, "\n")
+}
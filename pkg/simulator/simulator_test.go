@@ -0,0 +1,74 @@
+package simulator
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"legv8sim/pkg/cpu"
+	"legv8sim/pkg/disasm"
+)
+
+func TestStepArithmetic(t *testing.T) {
+	c := cpu.New(96)
+	c.Registers[1] = 10
+	c.Registers[2] = 3
+
+	inst := disasm.Instruction{Op: disasm.OpSUB, Format: disasm.FormatR, Fields: map[string]int64{"rd": 3, "rn": 1, "rm": 2}}
+	halted, err := Step(c, inst)
+	if halted || err != nil {
+		t.Fatalf("Step(SUB) = (%v, %v), want (false, nil)", halted, err)
+	}
+	if got, want := c.Registers[3], int64(7); got != want {
+		t.Errorf("X3 = %d, want %d", got, want)
+	}
+}
+
+func TestStepUnalignedSTUR(t *testing.T) {
+	c := cpu.New(96)
+	c.Registers[1] = 1 // not 8-byte aligned
+
+	inst := disasm.Instruction{Op: disasm.OpSTUR, Format: disasm.FormatD, Fields: map[string]int64{"address": 0, "rn": 1, "rt": 2}}
+	halted, err := Step(c, inst)
+	if !halted {
+		t.Fatal("Step(STUR to unaligned address) did not halt")
+	}
+	var machineErr *MachineError
+	if !errors.As(err, &machineErr) || machineErr.Kind != ErrUnalignedAccess {
+		t.Errorf("err = %v, want a MachineError with Kind ErrUnalignedAccess", err)
+	}
+}
+
+func TestStepBreakHalts(t *testing.T) {
+	c := cpu.New(96)
+	inst := disasm.Instruction{Op: disasm.OpBREAK, Fields: map[string]int64{}}
+	halted, err := Step(c, inst)
+	if !halted {
+		t.Fatal("Step(BREAK with X0 == 0) did not halt")
+	}
+	var machineErr *MachineError
+	if !errors.As(err, &machineErr) || machineErr.Kind != ErrBreakHit {
+		t.Errorf("err = %v, want a MachineError with Kind ErrBreakHit", err)
+	}
+}
+
+func TestRunFollowsTakenBranch(t *testing.T) {
+	// SUBI X1, X1, #1; CBNZ X1, #-1 (back to SUBI); BREAK, looping 3 times.
+	program := []disasm.Instruction{
+		{Op: disasm.OpSUBI, Format: disasm.FormatI, Fields: map[string]int64{"rd": 1, "rn": 1, "immediate": 1}},
+		{Op: disasm.OpCBNZ, Format: disasm.FormatCB, Fields: map[string]int64{"rt": 1, "offset": -1}},
+		{Op: disasm.OpBREAK, Fields: map[string]int64{}},
+	}
+
+	c := cpu.New(96)
+	c.Registers[1] = 3
+
+	err := Run(c, program, io.Discard)
+	var machineErr *MachineError
+	if !errors.As(err, &machineErr) || machineErr.Kind != ErrBreakHit {
+		t.Fatalf("Run() err = %v, want a MachineError with Kind ErrBreakHit", err)
+	}
+	if got := c.Registers[1]; got != 0 {
+		t.Errorf("X1 = %d, want 0 (loop should have executed 3 times)", got)
+	}
+}